@@ -0,0 +1,103 @@
+package pom
+
+import (
+	"context"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"hash"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/aquasecurity/trivy/pkg/log"
+	"golang.org/x/xerrors"
+)
+
+// ChecksumPolicy controls how fetched POMs are verified against the checksum
+// sidecar files (`.sha512`/`.sha256`/`.sha1`) Maven repositories publish next
+// to every artifact. `.md5` sidecars are not used.
+type ChecksumPolicy int
+
+const (
+	// ChecksumIgnore skips checksum verification entirely (default).
+	ChecksumIgnore ChecksumPolicy = iota
+	// ChecksumWarn verifies the checksum when a sidecar is available and logs a
+	// warning on mismatch, but still accepts the artifact.
+	ChecksumWarn
+	// ChecksumRequire rejects the artifact (and falls through to the next
+	// repository) when the checksum is missing or doesn't match.
+	ChecksumRequire
+)
+
+// WithChecksumPolicy enables verifying fetched POMs against their published
+// checksum sidecar, strongest available first (.sha512 -> .sha256 -> .sha1).
+func WithChecksumPolicy(policy ChecksumPolicy) option {
+	return func(opts *options) {
+		opts.checksumPolicy = policy
+	}
+}
+
+// checksumExtensions is ordered strongest-first, matching the order they should
+// be tried in.
+var checksumExtensions = []struct {
+	ext     string
+	newHash func() hash.Hash
+}{
+	{".sha512", sha512.New},
+	{".sha256", sha256.New},
+	{".sha1", sha1.New},
+}
+
+// verifyChecksum fetches the strongest available checksum sidecar for the
+// artifact at `paths` on `repo` and compares it against `data`. It returns nil
+// when verification passes or (depending on policy) isn't required; otherwise
+// it returns an error explaining why the artifact should be rejected.
+func (p *Parser) verifyChecksum(ctx context.Context, repo string, paths []string, data []byte) error {
+	if p.checksumPolicy == ChecksumIgnore {
+		return nil
+	}
+
+	for _, c := range checksumExtensions {
+		sidecarPaths := slices.Clone(paths)
+		sidecarPaths[len(sidecarPaths)-1] += c.ext
+
+		req, err := p.remoteRepoRequest(ctx, repo, sidecarPaths)
+		if err != nil {
+			continue
+		}
+
+		sidecarData, statusCode, err := p.cachedHTTPRequest(req, strings.Join(sidecarPaths, "/"))
+		if err != nil || statusCode != http.StatusOK {
+			continue
+		}
+
+		fields := strings.Fields(string(sidecarData))
+		if len(fields) == 0 {
+			p.logger.Debug("Checksum sidecar is empty, skipping", log.String("url", req.URL.String()))
+			continue
+		}
+
+		expected := strings.ToLower(fields[0])
+		h := c.newHash()
+		h.Write(data)
+		actual := hex.EncodeToString(h.Sum(nil))
+
+		if expected != actual {
+			err = xerrors.Errorf("checksum mismatch for %s (%s): expected %s, got %s", strings.Join(paths, "/"), c.ext, expected, actual)
+			p.logger.Warn("Checksum mismatch", log.String("url", req.URL.String()), log.String("expected", expected), log.String("actual", actual))
+			return err
+		}
+
+		p.logger.Debug("Checksum verified", log.String("url", req.URL.String()), log.String("algorithm", c.ext))
+		return nil
+	}
+
+	if p.checksumPolicy == ChecksumRequire {
+		return xerrors.Errorf("no checksum sidecar found for %s", strings.Join(paths, "/"))
+	}
+
+	p.logger.Debug("No checksum sidecar found, skipping verification", log.String("path", strings.Join(paths, "/")))
+	return nil
+}
@@ -0,0 +1,38 @@
+package pom
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestVerifyChecksumEmptySidecarDoesNotPanic exercises verifyChecksum through
+// the real remoteRepoRequest/cachedHTTPRequest call path against a repository
+// that serves an empty `.sha256`/`.sha1` sidecar body -- a realistic response
+// from a misconfigured or hostile upstream -- and asserts it falls through to
+// "no checksum sidecar found" instead of panicking on an empty Fields() slice.
+func TestVerifyChecksumEmptySidecarDoesNotPanic(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Every sidecar extension (.sha512/.sha256/.sha1) gets a 200 with an
+		// empty/whitespace-only body.
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("   \n"))
+	}))
+	defer srv.Close()
+
+	p := NewParser("", WithReleaseRemoteRepos([]string{srv.URL}), WithChecksumPolicy(ChecksumRequire))
+
+	assert.NotPanics(t, func() {
+		err := p.verifyChecksum(context.Background(), srv.URL, []string{"com", "example", "1.0", "example-1.0.pom"}, []byte("pom-data"))
+		assert.Error(t, err, "ChecksumRequire must reject when no usable sidecar was found")
+	})
+}
+
+func TestVerifyChecksumIgnorePolicySkipsFetch(t *testing.T) {
+	p := NewParser("", WithChecksumPolicy(ChecksumIgnore))
+	err := p.verifyChecksum(context.Background(), "https://repo.example.com/maven2/", []string{"x"}, []byte("data"))
+	assert.NoError(t, err)
+}
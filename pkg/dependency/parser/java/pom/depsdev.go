@@ -0,0 +1,209 @@
+package pom
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/aquasecurity/trivy/pkg/log"
+	"golang.org/x/xerrors"
+)
+
+// defaultDepsDevEndpoint is the public deps.dev API used as a last-resort
+// resolver when an artifact's POM can't be found in any configured repository.
+// cf. https://docs.deps.dev/api/v3/
+const defaultDepsDevEndpoint = "https://api.deps.dev"
+
+// depsDevVersion mirrors the subset of the deps.dev `GetVersion` response this
+// resolver needs: dependencyManagement imports and parent coordinates aren't
+// exposed over this API, so we approximate a POM well enough for `analyze` to
+// consume. The dependency list itself comes from a separate GetDependencies
+// call (depsDevDependencyGraph below).
+type depsDevVersion struct {
+	Licenses []string `json:"licenses"`
+	Links    struct {
+		Homepage string `json:"homepage"`
+	} `json:"links"`
+}
+
+// depsDevDependencyGraph mirrors the subset of deps.dev's `GetDependencies`
+// response this resolver needs: the resolved graph's nodes, keyed by
+// groupID:artifactID so we can flatten out the artifact's direct dependencies.
+// cf. https://docs.deps.dev/api/v3/#getdependencies
+type depsDevDependencyGraph struct {
+	Nodes []struct {
+		VersionKey struct {
+			System  string `json:"system"`
+			Name    string `json:"name"`
+			Version string `json:"version"`
+		} `json:"versionKey"`
+		Relation string `json:"relation"`
+	} `json:"nodes"`
+}
+
+// mavenCoordinate is a groupID:artifactID:version triple rendered into a
+// synthetic POM's <dependencies> block.
+type mavenCoordinate struct {
+	GroupID    string
+	ArtifactID string
+	Version    string
+}
+
+// WithDepsDevFallback enables consulting the deps.dev API for dependency
+// metadata when an artifact's POM can't be found in any local/remote
+// repository, e.g. when the true POM lives behind a private repo Trivy can't
+// reach. cf. osv-scanner's deps.dev integration.
+func WithDepsDevFallback(useDepsDevFallback bool) option {
+	return func(opts *options) {
+		opts.depsDevFallback = useDepsDevFallback
+	}
+}
+
+// WithDepsDevEndpoint overrides the deps.dev API endpoint, mainly for testing
+// or for proxying through an internal mirror.
+func WithDepsDevEndpoint(endpoint string) option {
+	return func(opts *options) {
+		opts.depsDevEndpoint = endpoint
+	}
+}
+
+// fetchPOMFromDepsDev queries deps.dev for groupID:artifactID:version and
+// synthesizes a POM (licenses plus direct dependencies; deps.dev doesn't
+// expose dependencyManagement imports or parent coordinates of a Maven
+// artifact over this API) so that the existing analyze/resolve pipeline can
+// still build out a transitive tree for the package.
+func (p *Parser) fetchPOMFromDepsDev(groupID, artifactID, version string) (*pom, error) {
+	endpoint := p.depsDevEndpoint
+	if endpoint == "" {
+		endpoint = defaultDepsDevEndpoint
+	}
+
+	name := url.PathEscape(fmt.Sprintf("%s:%s", groupID, artifactID))
+	reqURL := fmt.Sprintf("%s/v3/systems/maven/packages/%s/versions/%s", endpoint, name, url.PathEscape(version))
+
+	req, err := http.NewRequest(http.MethodGet, reqURL, http.NoBody)
+	if err != nil {
+		return nil, xerrors.Errorf("unable to create deps.dev request: %w", err)
+	}
+
+	data, statusCode, err := p.cachedHTTPRequest(req, strings.Join([]string{"deps.dev", "maven", groupID, artifactID, version}, "|"))
+	if err != nil {
+		return nil, xerrors.Errorf("deps.dev request error: %w", err)
+	} else if statusCode != http.StatusOK {
+		return nil, xerrors.Errorf("deps.dev returned status %d for %s:%s:%s", statusCode, groupID, artifactID, version)
+	}
+
+	var depsDevPkg depsDevVersion
+	if err = json.Unmarshal(data, &depsDevPkg); err != nil {
+		return nil, xerrors.Errorf("unable to parse deps.dev response: %w", err)
+	}
+
+	deps, err := p.fetchDepsDevDependencies(endpoint, groupID, artifactID, version)
+	if err != nil {
+		// Best-effort: a missing/failed dependency graph shouldn't sink the
+		// whole fallback, since licenses and coordinates are still useful.
+		p.logger.Debug("deps.dev dependency graph unavailable, synthesizing a childless POM",
+			log.String("group_id", groupID), log.String("artifact_id", artifactID), log.String("version", version), log.Err(err))
+	}
+
+	p.logger.Warn("Resolved via deps.dev fallback; dependencyManagement imports and parent coordinates for this artifact are not available from deps.dev and will be missing from the transitive tree",
+		log.String("group_id", groupID), log.String("artifact_id", artifactID), log.String("version", version))
+
+	content, err := parsePom(strings.NewReader(syntheticPomXML(groupID, artifactID, version, depsDevPkg.Licenses, deps)))
+	if err != nil {
+		return nil, xerrors.Errorf("unable to build synthetic POM: %w", err)
+	}
+
+	return &pom{
+		filePath: "", // synthesized from deps.dev, not backed by a file
+		content:  content,
+	}, nil
+}
+
+// fetchDepsDevDependencies queries deps.dev's GetDependencies endpoint and
+// flattens the resolved graph's direct dependencies (relation == "DIRECT")
+// into the groupID:artifactID:version triples a synthetic POM's
+// <dependencies> block needs; transitive resolution of those, in turn,
+// happens the normal way once they're fed back through the resolver.
+func (p *Parser) fetchDepsDevDependencies(endpoint, groupID, artifactID, version string) ([]mavenCoordinate, error) {
+	name := url.PathEscape(fmt.Sprintf("%s:%s", groupID, artifactID))
+	reqURL := fmt.Sprintf("%s/v3/systems/maven/packages/%s/versions/%s:dependencies", endpoint, name, url.PathEscape(version))
+
+	req, err := http.NewRequest(http.MethodGet, reqURL, http.NoBody)
+	if err != nil {
+		return nil, xerrors.Errorf("unable to create deps.dev dependencies request: %w", err)
+	}
+
+	data, statusCode, err := p.cachedHTTPRequest(req, strings.Join([]string{"deps.dev", "maven", groupID, artifactID, version, "dependencies"}, "|"))
+	if err != nil {
+		return nil, xerrors.Errorf("deps.dev dependencies request error: %w", err)
+	} else if statusCode != http.StatusOK {
+		return nil, xerrors.Errorf("deps.dev returned status %d for %s:%s:%s dependencies", statusCode, groupID, artifactID, version)
+	}
+
+	var graph depsDevDependencyGraph
+	if err = json.Unmarshal(data, &graph); err != nil {
+		return nil, xerrors.Errorf("unable to parse deps.dev dependencies response: %w", err)
+	}
+
+	var deps []mavenCoordinate
+	for _, node := range graph.Nodes {
+		if node.Relation != "DIRECT" || node.VersionKey.System != "MAVEN" {
+			continue
+		}
+		depGroupID, depArtifactID, ok := strings.Cut(node.VersionKey.Name, ":")
+		if !ok {
+			continue
+		}
+		deps = append(deps, mavenCoordinate{GroupID: depGroupID, ArtifactID: depArtifactID, Version: node.VersionKey.Version})
+	}
+	return deps, nil
+}
+
+// syntheticPomXML renders a minimal valid POM document for groupID:artifactID:version
+// so it can be parsed through the same `parsePom` path as a real POM.
+func syntheticPomXML(groupID, artifactID, version string, licenses []string, dependencies []mavenCoordinate) string {
+	var licensesXML strings.Builder
+	if len(licenses) > 0 {
+		licensesXML.WriteString("<licenses>")
+		for _, l := range licenses {
+			licensesXML.WriteString("<license><name>")
+			xml.EscapeText(&licensesXML, []byte(l))
+			licensesXML.WriteString("</name></license>")
+		}
+		licensesXML.WriteString("</licenses>")
+	}
+
+	var dependenciesXML strings.Builder
+	if len(dependencies) > 0 {
+		dependenciesXML.WriteString("<dependencies>")
+		for _, d := range dependencies {
+			dependenciesXML.WriteString("<dependency><groupId>")
+			xml.EscapeText(&dependenciesXML, []byte(d.GroupID))
+			dependenciesXML.WriteString("</groupId><artifactId>")
+			xml.EscapeText(&dependenciesXML, []byte(d.ArtifactID))
+			dependenciesXML.WriteString("</artifactId><version>")
+			xml.EscapeText(&dependenciesXML, []byte(d.Version))
+			dependenciesXML.WriteString("</version></dependency>")
+		}
+		dependenciesXML.WriteString("</dependencies>")
+	}
+
+	return fmt.Sprintf(`<project>
+  <modelVersion>4.0.0</modelVersion>
+  <groupId>%s</groupId>
+  <artifactId>%s</artifactId>
+  <version>%s</version>
+  %s
+  %s
+</project>`, xmlEscape(groupID), xmlEscape(artifactID), xmlEscape(version), licensesXML.String(), dependenciesXML.String())
+}
+
+func xmlEscape(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
@@ -0,0 +1,54 @@
+package pom
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyntheticPomXML(t *testing.T) {
+	got := syntheticPomXML("com.example", "my-lib", "1.0.0", []string{"Apache-2.0", "MIT & BSD"}, nil)
+
+	assert.Contains(t, got, "<groupId>com.example</groupId>")
+	assert.Contains(t, got, "<artifactId>my-lib</artifactId>")
+	assert.Contains(t, got, "<version>1.0.0</version>")
+	assert.Contains(t, got, "<name>Apache-2.0</name>")
+	assert.Contains(t, got, "<name>MIT &amp; BSD</name>")
+
+	_, err := parsePom(strings.NewReader(got))
+	assert.NoError(t, err, "synthesized POM must parse through the same path as a real POM")
+}
+
+func TestSyntheticPomXMLWithoutLicenses(t *testing.T) {
+	got := syntheticPomXML("com.example", "my-lib", "1.0.0", nil, nil)
+	assert.NotContains(t, got, "<licenses>")
+
+	_, err := parsePom(strings.NewReader(got))
+	assert.NoError(t, err)
+}
+
+func TestSyntheticPomXMLWithDependencies(t *testing.T) {
+	deps := []mavenCoordinate{
+		{GroupID: "com.example", ArtifactID: "dep-a", Version: "2.0"},
+		{GroupID: "com.example", ArtifactID: "dep-b", Version: "3.0"},
+	}
+	got := syntheticPomXML("com.example", "my-lib", "1.0.0", nil, deps)
+
+	assert.Contains(t, got, "<dependencies>")
+	assert.Contains(t, got, "<artifactId>dep-a</artifactId>")
+	assert.Contains(t, got, "<artifactId>dep-b</artifactId>")
+
+	content, err := parsePom(strings.NewReader(got))
+	assert.NoError(t, err, "synthesized POM with dependencies must parse through the same path as a real POM")
+	assert.Len(t, content.Dependencies.Dependency, 2)
+}
+
+func TestSyntheticPomXMLWithoutDependencies(t *testing.T) {
+	got := syntheticPomXML("com.example", "my-lib", "1.0.0", nil, nil)
+	assert.NotContains(t, got, "<dependencies>")
+}
+
+func TestXmlEscape(t *testing.T) {
+	assert.Equal(t, "a &amp; b &lt;c&gt;", xmlEscape("a & b <c>"))
+}
@@ -0,0 +1,128 @@
+package pom
+
+import (
+	"encoding/xml"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/log"
+	"golang.org/x/xerrors"
+)
+
+// defaultMavenCommand is the `mvn` binary invoked by WithUseMavenCLI.
+const defaultMavenCommand = "mvn"
+
+// WithUseMavenCLI delegates POM resolution to an external `mvn help:effective-pom`
+// invocation instead of the in-process resolver, for projects too complex for our
+// homegrown resolution (BOMs, profiles, `${project.parent.relativePath}` tricks).
+// Falls back to the internal resolver if `mvn` is absent or exits non-zero.
+func WithUseMavenCLI(useMavenCLI bool) option {
+	return func(opts *options) {
+		opts.useMavenCLI = useMavenCLI
+	}
+}
+
+// WithMavenCommand overrides the `mvn` binary/path used by WithUseMavenCLI.
+func WithMavenCommand(mavenCommand string) option {
+	return func(opts *options) {
+		opts.mavenCommand = mavenCommand
+	}
+}
+
+// parseWithMavenCLI shells out to `mvn help:effective-pom`, parses the effective POM
+// it produces, and feeds it through the normal analyze/parseRoot pipeline so the
+// resulting packages/dependencies are indistinguishable from the internal resolver's
+// output. `ok` is false (with a nil error) whenever it's reasonable to silently fall
+// back to the internal resolver, e.g. `mvn` isn't installed.
+func (p *Parser) parseWithMavenCLI() (pkgs []ftypes.Package, deps []ftypes.Dependency, ok bool, err error) {
+	mavenCommand := p.mavenCommand
+	if mavenCommand == "" {
+		mavenCommand = defaultMavenCommand
+	}
+
+	if _, err := exec.LookPath(mavenCommand); err != nil {
+		p.logger.Debug("Maven CLI not found, using the internal resolver", log.String("command", mavenCommand))
+		return nil, nil, false, nil
+	}
+
+	tmpFile, err := os.CreateTemp("", "trivy-effective-pom-*.xml")
+	if err != nil {
+		return nil, nil, false, xerrors.Errorf("unable to create temp file for effective POM: %w", err)
+	}
+	tmpPath := tmpFile.Name()
+	tmpFile.Close()
+	defer os.Remove(tmpPath)
+
+	// -N (--non-recursive) restricts execution to p.rootPath itself: without it,
+	// `mvn help:effective-pom` walks the whole reactor for a multi-module
+	// aggregator and wraps every module's effective POM in a <projects>
+	// element instead of emitting the single <project> parsePom expects.
+	cmd := exec.Command(mavenCommand, "-q", "-N", "help:effective-pom", "-f", p.rootPath, "-Doutput="+tmpPath)
+	cmd.Dir = filepath.Dir(p.rootPath)
+	if out, runErr := cmd.CombinedOutput(); runErr != nil {
+		p.logger.Debug("mvn help:effective-pom failed, using the internal resolver",
+			log.String("output", string(out)), log.Err(runErr))
+		return nil, nil, false, nil
+	}
+
+	f, err := os.Open(tmpPath)
+	if err != nil {
+		return nil, nil, false, xerrors.Errorf("unable to open effective POM: %w", err)
+	}
+	defer f.Close()
+
+	// Defense in depth: -N should already guarantee a single <project>, but
+	// fall back rather than silently feed parsePom a <projects> reactor
+	// wrapper it was never meant to decode (some older `mvn help` plugin
+	// versions ignore -N for this goal).
+	rootElem, err := effectivePomRootElement(f)
+	if err != nil {
+		return nil, nil, false, xerrors.Errorf("unable to read effective POM: %w", err)
+	}
+	if rootElem != "project" {
+		p.logger.Debug("mvn help:effective-pom produced a multi-module reactor output, using the internal resolver",
+			log.String("rootElement", rootElem))
+		return nil, nil, false, nil
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, nil, false, xerrors.Errorf("unable to rewind effective POM: %w", err)
+	}
+
+	content, err := parsePom(f)
+	if err != nil {
+		return nil, nil, false, xerrors.Errorf("unable to parse effective POM: %w", err)
+	}
+
+	root := &pom{filePath: p.rootPath, content: content}
+	result, err := p.analyze(root, analysisOptions{lineNumber: true}, map[string]struct{}{})
+	if err != nil {
+		return nil, nil, false, xerrors.Errorf("analyze error (%s): %w", p.rootPath, err)
+	}
+	p.cache.put(result.artifact, result)
+
+	pkgs, deps, err = p.parseRoot(root.artifact(), make(map[string]struct{}), map[string]struct{}{})
+	if err != nil {
+		return nil, nil, false, err
+	}
+	return pkgs, deps, true, nil
+}
+
+// effectivePomRootElement returns the local name of the outermost XML element
+// read from r -- "project" for a single effective POM, or "projects" for the
+// multi-module reactor wrapper `mvn help:effective-pom` emits when it walks
+// more than one module.
+func effectivePomRootElement(r io.Reader) (string, error) {
+	decoder := xml.NewDecoder(r)
+	for {
+		tok, err := decoder.Token()
+		if err != nil {
+			return "", err
+		}
+		if se, ok := tok.(xml.StartElement); ok {
+			return se.Name.Local, nil
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package pom
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aquasecurity/trivy/pkg/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseWithMavenCLIFallsBackWhenCommandNotFound(t *testing.T) {
+	p := &Parser{
+		logger:       log.WithPrefix("pom"),
+		mavenCommand: "trivy-pom-test-mvn-that-does-not-exist",
+	}
+
+	pkgs, deps, ok, err := p.parseWithMavenCLI()
+	assert.NoError(t, err)
+	assert.False(t, ok, "a missing mvn binary should fall back to the internal resolver, not error")
+	assert.Nil(t, pkgs)
+	assert.Nil(t, deps)
+}
+
+// writeFakeMavenCommand writes an executable shell script standing in for
+// `mvn`: whatever effectivePom it's given is written to the `-Doutput=` path
+// the real `help:effective-pom` goal would have populated.
+func writeFakeMavenCommand(t *testing.T, effectivePom string) string {
+	t.Helper()
+
+	script := "#!/bin/sh\n" +
+		"for arg in \"$@\"; do\n" +
+		"  case \"$arg\" in\n" +
+		"    -Doutput=*) out=\"${arg#-Doutput=}\" ;;\n" +
+		"  esac\n" +
+		"done\n" +
+		"cat > \"$out\" <<'EFFECTIVE_POM'\n" + effectivePom + "\nEFFECTIVE_POM\n"
+
+	path := filepath.Join(t.TempDir(), "fake-mvn.sh")
+	assert.NoError(t, os.WriteFile(path, []byte(script), 0o755))
+	return path
+}
+
+func TestParseWithMavenCLIFallsBackOnMultiModuleReactorOutput(t *testing.T) {
+	// A real `mvn help:effective-pom` run against a multi-module aggregator
+	// (without -N, or with an `mvn help` plugin version that ignores it)
+	// wraps every reactor module's effective POM in <projects>, which
+	// parsePom's single-<project> decoder can't make sense of.
+	fakeMvn := writeFakeMavenCommand(t, `<projects>
+<project><groupId>com.example</groupId><artifactId>module-a</artifactId><version>1.0</version></project>
+<project><groupId>com.example</groupId><artifactId>module-b</artifactId><version>1.0</version></project>
+</projects>`)
+
+	rootPath := filepath.Join(t.TempDir(), "pom.xml")
+	assert.NoError(t, os.WriteFile(rootPath, []byte("<project></project>"), 0o644))
+
+	p := NewParser(rootPath, WithUseMavenCLI(true), WithMavenCommand(fakeMvn))
+
+	pkgs, deps, ok, err := p.parseWithMavenCLI()
+	assert.NoError(t, err)
+	assert.False(t, ok, "a multi-module <projects> wrapper must fall back to the internal resolver, not proceed with an empty/bogus artifact")
+	assert.Nil(t, pkgs)
+	assert.Nil(t, deps)
+}
+
+func TestEffectivePomRootElement(t *testing.T) {
+	elem, err := effectivePomRootElement(strings.NewReader(`<?xml version="1.0"?><project><groupId>g</groupId></project>`))
+	assert.NoError(t, err)
+	assert.Equal(t, "project", elem)
+
+	elem, err = effectivePomRootElement(strings.NewReader(`<projects><project/><project/></projects>`))
+	assert.NoError(t, err)
+	assert.Equal(t, "projects", elem)
+}
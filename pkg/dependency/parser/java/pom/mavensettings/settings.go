@@ -0,0 +1,167 @@
+// Package mavensettings parses Maven's `settings.xml` (mirrors, proxies and
+// authenticated servers) so the pom parser can resolve artifacts the same way
+// `mvn` itself would against an internal Artifactory/Nexus mirror.
+package mavensettings
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// Settings is the subset of `settings.xml` relevant to repository resolution.
+type Settings struct {
+	XMLName         xml.Name `xml:"settings"`
+	LocalRepository string   `xml:"localRepository"`
+	Servers         []Server `xml:"servers>server"`
+	Mirrors         []Mirror `xml:"mirrors>mirror"`
+	Proxies         []Proxy  `xml:"proxies>proxy"`
+}
+
+// Server holds credentials for a repository id, either basic-auth
+// (username/password) or a bearer token via `<configuration><httpHeaders>`
+// conventions used by several Maven credential providers.
+type Server struct {
+	ID          string `xml:"id"`
+	Username    string `xml:"username"`
+	Password    string `xml:"password"`
+	PrivateKey  string `xml:"privateKey"`
+	Passphrase  string `xml:"passphrase"`
+	BearerToken string `xml:"configuration>httpHeaders>property>value"`
+}
+
+// Mirror rewrites/intercepts requests to repositories matching MirrorOf.
+type Mirror struct {
+	ID       string `xml:"id"`
+	URL      string `xml:"url"`
+	MirrorOf string `xml:"mirrorOf"`
+}
+
+// Proxy is a `<proxy>` entry used to reach a repository through an HTTP(S) proxy.
+type Proxy struct {
+	ID            string `xml:"id"`
+	Active        bool   `xml:"active"`
+	Protocol      string `xml:"protocol"`
+	Host          string `xml:"host"`
+	Port          int    `xml:"port"`
+	Username      string `xml:"username"`
+	Password      string `xml:"password"`
+	NonProxyHosts string `xml:"nonProxyHosts"`
+}
+
+// Load parses the `settings.xml` file at path.
+func Load(path string) (*Settings, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, xerrors.Errorf("unable to open settings.xml (%s): %w", path, err)
+	}
+	defer f.Close()
+
+	var s Settings
+	if err = xml.NewDecoder(f).Decode(&s); err != nil {
+		return nil, xerrors.Errorf("unable to parse settings.xml (%s): %w", path, err)
+	}
+	return &s, nil
+}
+
+// LoadDefault loads settings.xml from the conventional locations Maven itself
+// checks (`$MAVEN_CONFIG`, then `~/.m2/settings.xml`), returning an empty,
+// non-nil Settings if none is found or it can't be parsed. It never returns an
+// error, since callers use it for best-effort auto-detection, not a required
+// configuration file.
+func LoadDefault() *Settings {
+	paths := []string{os.Getenv("MAVEN_CONFIG")}
+	if homeDir, err := os.UserHomeDir(); err == nil {
+		paths = append(paths, filepath.Join(homeDir, ".m2", "settings.xml"))
+	}
+
+	for _, p := range paths {
+		if p == "" {
+			continue
+		}
+		if s, err := Load(p); err == nil {
+			return s
+		}
+	}
+	return &Settings{}
+}
+
+// MatchMirror returns the first mirror whose `mirrorOf` matches repoID, per
+// Maven's own mirror selection syntax: `*` (everything), `external:*` (every
+// non-local repository), a comma-separated allow list, and `!repoId` negation.
+// cf. https://maven.apache.org/guides/mini/guide-mirror-settings.html#how-does-it-work
+func MatchMirror(mirrors []Mirror, repoID string) (Mirror, bool) {
+	for _, m := range mirrors {
+		if mirrorOfMatches(m.MirrorOf, repoID) {
+			return m, true
+		}
+	}
+	return Mirror{}, false
+}
+
+// mirrorOfMatches mirrors Maven's own DefaultMirrorSelector.matchPattern: a
+// positive token (`*`, `external:*`, or a literal repo id) only sets a
+// tentative match and keeps scanning, since a later negation in the same
+// list -- e.g. the canonical `*,!repo1` -- must still be able to veto it. A
+// negation, by contrast, is authoritative: as soon as `!repoID` is seen, the
+// pattern list can never match, regardless of what came before or comes after.
+func mirrorOfMatches(mirrorOf, repoID string) bool {
+	if mirrorOf == "" {
+		return false
+	}
+
+	matched := false
+	for _, pattern := range strings.Split(mirrorOf, ",") {
+		pattern = strings.TrimSpace(pattern)
+		negated := strings.HasPrefix(pattern, "!")
+		if negated {
+			pattern = strings.TrimPrefix(pattern, "!")
+		}
+
+		if pattern != repoID && pattern != "*" && !(pattern == "external:*" && repoID != "local") {
+			continue
+		}
+
+		if negated {
+			return false
+		}
+		matched = true
+	}
+	return matched
+}
+
+// ServerFor returns the `<server>` entry whose id matches repoID.
+func ServerFor(servers []Server, repoID string) (Server, bool) {
+	for _, s := range servers {
+		if s.ID == repoID {
+			return s, true
+		}
+	}
+	return Server{}, false
+}
+
+// AuthHeader returns the `Authorization` header value to use for a server, preferring
+// a configured bearer token over basic-auth credentials.
+func AuthHeader(s Server) (value string, ok bool) {
+	switch {
+	case s.BearerToken != "":
+		return "Bearer " + s.BearerToken, true
+	case s.Username != "":
+		return "", false // caller should use req.SetBasicAuth instead
+	default:
+		return "", false
+	}
+}
+
+// ActiveProxyFor returns the first active proxy matching protocol, if any.
+func ActiveProxyFor(proxies []Proxy, protocol string) (Proxy, bool) {
+	for _, px := range proxies {
+		if px.Active && (px.Protocol == "" || px.Protocol == protocol) {
+			return px, true
+		}
+	}
+	return Proxy{}, false
+}
@@ -0,0 +1,107 @@
+package mavensettings
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchMirror(t *testing.T) {
+	tests := []struct {
+		name    string
+		mirrors []Mirror
+		repoID  string
+		want    string // expected mirror ID, empty if no match
+	}{
+		{
+			name:    "wildcard matches everything",
+			mirrors: []Mirror{{ID: "central-mirror", URL: "https://mirror.example.com/maven2", MirrorOf: "*"}},
+			repoID:  "central",
+			want:    "central-mirror",
+		},
+		{
+			name:    "external wildcard skips local",
+			mirrors: []Mirror{{ID: "external-mirror", MirrorOf: "external:*"}},
+			repoID:  "local",
+			want:    "",
+		},
+		{
+			name:    "external wildcard matches non-local",
+			mirrors: []Mirror{{ID: "external-mirror", MirrorOf: "external:*"}},
+			repoID:  "central",
+			want:    "external-mirror",
+		},
+		{
+			name:    "comma list matches one of several repo ids",
+			mirrors: []Mirror{{ID: "mirror", MirrorOf: "repo1,repo2"}},
+			repoID:  "repo2",
+			want:    "mirror",
+		},
+		{
+			name:    "negation excludes a specific repo id from wildcard",
+			mirrors: []Mirror{{ID: "mirror", MirrorOf: "*,!repo1"}},
+			repoID:  "repo1",
+			want:    "",
+		},
+		{
+			name:    "negation still matches other repo ids",
+			mirrors: []Mirror{{ID: "mirror", MirrorOf: "*,!repo1"}},
+			repoID:  "repo2",
+			want:    "mirror",
+		},
+		{
+			name:    "no mirrors configured",
+			mirrors: nil,
+			repoID:  "central",
+			want:    "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := MatchMirror(tt.mirrors, tt.repoID)
+			if tt.want == "" {
+				assert.False(t, ok)
+				return
+			}
+			assert.True(t, ok)
+			assert.Equal(t, tt.want, got.ID)
+		})
+	}
+}
+
+func TestServerFor(t *testing.T) {
+	servers := []Server{
+		{ID: "internal-repo", Username: "alice", Password: "secret"},
+		{ID: "artifactory", BearerToken: "tok-123"},
+	}
+
+	t.Run("matching id", func(t *testing.T) {
+		s, ok := ServerFor(servers, "artifactory")
+		assert.True(t, ok)
+		assert.Equal(t, "tok-123", s.BearerToken)
+	})
+
+	t.Run("no matching id", func(t *testing.T) {
+		_, ok := ServerFor(servers, "unknown")
+		assert.False(t, ok)
+	})
+}
+
+func TestAuthHeader(t *testing.T) {
+	t.Run("bearer token takes precedence", func(t *testing.T) {
+		header, ok := AuthHeader(Server{Username: "alice", BearerToken: "tok-123"})
+		assert.True(t, ok)
+		assert.Equal(t, "Bearer tok-123", header)
+	})
+
+	t.Run("basic auth defers to SetBasicAuth", func(t *testing.T) {
+		_, ok := AuthHeader(Server{Username: "alice", Password: "secret"})
+		assert.False(t, ok)
+	})
+
+	t.Run("no credentials", func(t *testing.T) {
+		_, ok := AuthHeader(Server{})
+		assert.False(t, ok)
+	})
+}
@@ -1,6 +1,7 @@
 package pom
 
 import (
+	"context"
 	"encoding/xml"
 	"fmt"
 	"io"
@@ -12,8 +13,11 @@ import (
 	"slices"
 	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/aquasecurity/trivy/pkg/dependency"
+	"github.com/aquasecurity/trivy/pkg/dependency/parser/java/pom/mavensettings"
 	"github.com/aquasecurity/trivy/pkg/dependency/parser/utils"
 	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
 	"github.com/aquasecurity/trivy/pkg/log"
@@ -41,11 +45,21 @@ func init() {
 }
 
 type options struct {
-	offline             bool
-	useMavenCache       bool
-	mavenCacheTtl       int
-	releaseRemoteRepos  []string
-	snapshotRemoteRepos []string
+	offline                 bool
+	useMavenCache           bool
+	mavenCacheTtl           int
+	releaseRemoteRepos      []string
+	snapshotRemoteRepos     []string
+	useMavenLocalRepository bool
+	mavenLocalRepositoryDir string
+	depsDevFallback         bool
+	depsDevEndpoint         string
+	useMavenCLI             bool
+	mavenCommand            string
+	snapshotMetadataTtl     int
+	checksumPolicy          ChecksumPolicy
+	settings                *mavensettings.Settings
+	maxConcurrentFetches    int
 }
 
 type option func(*options)
@@ -80,16 +94,81 @@ func WithSnapshotRemoteRepos(repos []string) option {
 	}
 }
 
+// WithMaxConcurrentFetches bounds how many remote repositories are queried in
+// parallel when resolving an artifact, so a single slow repository doesn't
+// block every other configured repository behind it.
+func WithMaxConcurrentFetches(maxConcurrentFetches int) option {
+	return func(opts *options) {
+		opts.maxConcurrentFetches = maxConcurrentFetches
+	}
+}
+
+// WithSnapshotMetadataTtl sets the TTL (in minutes) used when caching
+// `maven-metadata.xml` lookups for SNAPSHOT artifacts. These change far more
+// frequently than a resolved `-x.y.z.pom`, so they get their own, shorter TTL
+// instead of sharing WithMavenCacheTtl.
+func WithSnapshotMetadataTtl(ttl int) option {
+	return func(opts *options) {
+		opts.snapshotMetadataTtl = ttl
+	}
+}
+
+// WithSettings provides explicitly parsed settings.xml mirrors/servers/proxies
+// (via the `mavensettings` package), taking precedence over the settings Trivy
+// otherwise auto-detects from `~/.m2/settings.xml`.
+func WithSettings(settings *mavensettings.Settings) option {
+	return func(opts *options) {
+		opts.settings = settings
+	}
+}
+
+// WithUseMavenLocalRepository enables resolving artifacts from the local Maven
+// repository (`~/.m2/repository` by default) before falling back to remote
+// repositories. This mirrors Maven's own resolution order and avoids redundant
+// network traffic in air-gapped CI where `mvn` has already populated `.m2`.
+func WithUseMavenLocalRepository(useMavenLocalRepository bool) option {
+	return func(opts *options) {
+		opts.useMavenLocalRepository = useMavenLocalRepository
+	}
+}
+
+// WithMavenLocalRepositoryDir overrides the directory used as the local Maven
+// repository. When unset, the directory is taken from `settings.xml` or
+// defaults to `~/.m2/repository`.
+func WithMavenLocalRepositoryDir(dir string) option {
+	return func(opts *options) {
+		opts.mavenLocalRepositoryDir = dir
+	}
+}
+
 type Parser struct {
-	logger              *log.Logger
-	rootPath            string
-	cache               pomCache
-	mavenHttpCache      *mavenHttpCache
-	localRepository     string
-	releaseRemoteRepos  []string
-	snapshotRemoteRepos []string
-	offline             bool
-	servers             []Server
+	logger                  *log.Logger
+	rootPath                string
+	cache                   pomCache
+	mavenHttpCache          *mavenHttpCache
+	localRepository         string
+	releaseRemoteRepos      []string
+	snapshotRemoteRepos     []string
+	offline                 bool
+	servers                 []Server
+	useMavenLocalRepository bool
+	depsDevFallback         bool
+	depsDevEndpoint         string
+	useMavenCLI             bool
+	mavenCommand            string
+	metadataHttpCache       *mavenHttpCache
+	checksumPolicy          ChecksumPolicy
+	settings                *mavensettings.Settings
+	maxConcurrentFetches    int
+	pomValidatorCache       *validatorCache
+	metadataValidatorCache  *validatorCache
+	// mavenHttpCacheMu/metadataHttpCacheMu serialize access to the
+	// corresponding *mavenHttpCache: that type was only ever driven by a
+	// single sequential call path before the concurrent repository fan-out
+	// (fetchPOMFromRemoteRepositories) was introduced, and isn't safe for
+	// concurrent get/set/domainTimeouts/blocklistDomain access on its own.
+	mavenHttpCacheMu    sync.Mutex
+	metadataHttpCacheMu sync.Mutex
 }
 
 func NewParser(filePath string, opts ...option) *Parser {
@@ -114,27 +193,71 @@ func NewParser(filePath string, opts ...option) *Parser {
 		homeDir, _ := os.UserHomeDir()
 		localRepository = filepath.Join(homeDir, ".m2", "repository")
 	}
+	if o.mavenLocalRepositoryDir != "" {
+		localRepository = o.mavenLocalRepositoryDir
+	}
 
-	var mavenHttpCache *mavenHttpCache = nil
+	// Mirrors/servers/proxies are resolved through a single settings
+	// representation: an explicit WithSettings takes precedence, otherwise fall
+	// back to auto-detecting settings.xml the same way `mvn` itself would.
+	settings := o.settings
+	if settings == nil {
+		settings = mavensettings.LoadDefault()
+	}
+
+	var mavenHttpCache, metadataHttpCache *mavenHttpCache = nil, nil
+	var pomValidatorCache, metadataValidatorCache *validatorCache = nil, nil
 
 	if o.useMavenCache {
 		mavenHttpCache = newMavenHttpCache(logger, o.mavenCacheTtl)
+		pomValidatorCache = newValidatorCache(time.Duration(o.mavenCacheTtl) * time.Minute)
+
+		snapshotMetadataTtl := o.snapshotMetadataTtl
+		if snapshotMetadataTtl == 0 {
+			snapshotMetadataTtl = defaultSnapshotMetadataTtl
+		}
+		metadataHttpCache = newMavenHttpCache(logger, snapshotMetadataTtl)
+		metadataValidatorCache = newValidatorCache(time.Duration(snapshotMetadataTtl) * time.Minute)
 	}
 
 	return &Parser{
-		logger:              logger,
-		rootPath:            filepath.Clean(filePath),
-		cache:               newPOMCache(),
-		mavenHttpCache:      mavenHttpCache,
-		localRepository:     localRepository,
-		releaseRemoteRepos:  o.releaseRemoteRepos,
-		snapshotRemoteRepos: o.snapshotRemoteRepos,
-		offline:             o.offline,
-		servers:             s.Servers,
+		logger:                  logger,
+		rootPath:                filepath.Clean(filePath),
+		cache:                   newPOMCache(),
+		mavenHttpCache:          mavenHttpCache,
+		metadataHttpCache:       metadataHttpCache,
+		localRepository:         localRepository,
+		releaseRemoteRepos:      o.releaseRemoteRepos,
+		snapshotRemoteRepos:     o.snapshotRemoteRepos,
+		offline:                 o.offline,
+		servers:                 s.Servers,
+		useMavenLocalRepository: o.useMavenLocalRepository,
+		depsDevFallback:         o.depsDevFallback,
+		depsDevEndpoint:         o.depsDevEndpoint,
+		useMavenCLI:             o.useMavenCLI,
+		mavenCommand:            o.mavenCommand,
+		checksumPolicy:          o.checksumPolicy,
+		settings:                settings,
+		maxConcurrentFetches:    o.maxConcurrentFetches,
+		pomValidatorCache:       pomValidatorCache,
+		metadataValidatorCache:  metadataValidatorCache,
 	}
 }
 
+// defaultSnapshotMetadataTtl (minutes) is much shorter than the default
+// WithMavenCacheTtl, since maven-metadata.xml for SNAPSHOT artifacts is
+// expected to change frequently.
+const defaultSnapshotMetadataTtl = 15
+
 func (p *Parser) Parse(r xio.ReadSeekerAt) ([]ftypes.Package, []ftypes.Dependency, error) {
+	if p.useMavenCLI {
+		if pkgs, deps, ok, err := p.parseWithMavenCLI(); err != nil {
+			p.logger.Warn("Maven CLI resolution failed, falling back to the internal resolver", log.Err(err))
+		} else if ok {
+			return pkgs, deps, nil
+		}
+	}
+
 	content, err := parsePom(r)
 	if err != nil {
 		return nil, nil, xerrors.Errorf("failed to parse POM: %w", err)
@@ -423,6 +546,14 @@ func (p *Parser) analyze(pom *pom, opts analysisOptions, visitedLocalPaths map[s
 	// Generate properties
 	props := pom.properties()
 
+	// A property defined in a grandparent (or higher) and never overridden is still
+	// visible to `${foo}` substitution in this POM, but `pom.properties()` above only
+	// reflects the immediate parent. Accumulate the rest of the chain so we don't
+	// silently drop dependencies whose version resolves to "".
+	props = addMissingProperties(props, parent.properties)
+	props = addProjectPseudoProperties(props, pom)
+	p.logger.Debug("analyze: addMissingProperties success")
+
 	// dependencyManagements have the next priority:
 	// 1. Managed dependencies from this POM
 	// 2. Managed dependencies from parent of this POM
@@ -447,6 +578,55 @@ func (p *Parser) analyze(pom *pom, opts analysisOptions, visitedLocalPaths map[s
 	}, nil
 }
 
+// addMissingProperties fills in any property defined by an ancestor POM (grandparent
+// or higher) that isn't already defined in props, without overwriting anything props
+// already has -- the nearest definition in the hierarchy always wins.
+func addMissingProperties(props, ancestorProps map[string]string) map[string]string {
+	if len(ancestorProps) == 0 {
+		return props
+	}
+	merged := make(map[string]string, len(props)+len(ancestorProps))
+	for k, v := range ancestorProps {
+		merged[k] = v
+	}
+	for k, v := range props {
+		merged[k] = v
+	}
+	return merged
+}
+
+// addProjectPseudoProperties exposes the standard Maven pseudo-properties that
+// real-world POMs use extensively but which aren't declared in any <properties>
+// block: ${project.groupId}, ${project.version}, ${project.parent.groupId}, and
+// ${project.parent.version}. They're derived from the effective (post-inherit)
+// artifact, so they're only added if not already set explicitly.
+func addProjectPseudoProperties(props map[string]string, pom *pom) map[string]string {
+	if props == nil {
+		props = make(map[string]string)
+	}
+
+	art := pom.artifact()
+	if _, ok := props["project.groupId"]; !ok && art.GroupID != "" {
+		props["project.groupId"] = art.GroupID
+	}
+	if _, ok := props["project.version"]; !ok && art.Version.String() != "" {
+		props["project.version"] = art.Version.String()
+	}
+
+	if pom.content.Parent.GroupId != "" {
+		if _, ok := props["project.parent.groupId"]; !ok {
+			props["project.parent.groupId"] = pom.content.Parent.GroupId
+		}
+	}
+	if pom.content.Parent.Version != "" {
+		if _, ok := props["project.parent.version"]; !ok {
+			props["project.parent.version"] = pom.content.Parent.Version
+		}
+	}
+
+	return props
+}
+
 func (p *Parser) mergeDependencyManagements(depManagements ...[]pomDependency) []pomDependency {
 	uniq := make(map[string]struct{})
 	var depManagement []pomDependency
@@ -491,7 +671,29 @@ func (p *Parser) parseDependencies(deps []pomDependency, props map[string]string
 	return dependencies
 }
 
+// maxImportDepth bounds how many `<scope>import</scope>` BOMs we'll follow
+// transitively, as a backstop against cyclic imports (e.g. two BOMs importing
+// each other through mismatched coordinates).
+const maxImportDepth = 10
+
 func (p *Parser) resolveDepManagement(props map[string]string, depManagement []pomDependency, visitedLocalPaths map[string]struct{}) []pomDependency {
+	return p.resolveDepManagementDepth(props, depManagement, visitedLocalPaths, make(map[string]struct{}), 0)
+}
+
+// shouldFollowImport reports whether the import BOM identified by key should
+// be walked, recording it as visited as a side effect. It returns false for a
+// key already present in visited, guarding against a BOM that (directly or
+// transitively) imports itself.
+func shouldFollowImport(visited map[string]struct{}, key string) bool {
+	if _, seen := visited[key]; seen {
+		return false
+	}
+	visited[key] = struct{}{}
+	return true
+}
+
+func (p *Parser) resolveDepManagementDepth(props map[string]string, depManagement []pomDependency,
+	visitedLocalPaths, visitedImports map[string]struct{}, depth int) []pomDependency {
 	var newDepManagement, imports []pomDependency
 	for _, dep := range depManagement {
 		// cf. https://howtodoinjava.com/maven/maven-dependency-scopes/#import
@@ -503,10 +705,28 @@ func (p *Parser) resolveDepManagement(props map[string]string, depManagement []p
 		}
 	}
 
+	if depth >= maxImportDepth {
+		p.logger.Debug("Maximum import BOM depth reached, stopping recursion", log.Int("depth", depth))
+		return newDepManagement
+	}
+
 	// Managed dependencies with a scope of "import" should be processed after other managed dependencies.
 	// cf. https://maven.apache.org/guides/introduction/introduction-to-dependency-mechanism.html#importing-dependencies
 	for _, imp := range imports {
 		art := newArtifact(imp.GroupID, imp.ArtifactID, imp.Version, nil, props)
+
+		// Guard against cyclic imports: a BOM that (directly or transitively)
+		// imports itself must not be walked twice.
+		key := art.String()
+		if !shouldFollowImport(visitedImports, key) {
+			p.logger.Debug("Import BOM already visited, skipping to avoid a cycle", log.String("artifact", key))
+			continue
+		}
+
+		// `p.resolve` -> `p.analyze` -> `p.parseParent` already walks the imported POM's
+		// own parent chain and merges properties/dependencyManagement bottom-up (child
+		// wins), so the BOM's managed versions are correct even when they're actually
+		// declared several grandparents up (common with Spring Boot/Quarkus BOMs).
 		result, err := p.resolve(art, nil, visitedLocalPaths)
 		if err != nil {
 			continue
@@ -515,7 +735,7 @@ func (p *Parser) resolveDepManagement(props map[string]string, depManagement []p
 		// We need to recursively check all nested depManagements,
 		// so that we don't miss dependencies on nested depManagements with `Import` scope.
 		newProps := utils.MergeMaps(props, result.properties)
-		result.dependencyManagement = p.resolveDepManagement(newProps, result.dependencyManagement, visitedLocalPaths)
+		result.dependencyManagement = p.resolveDepManagementDepth(newProps, result.dependencyManagement, visitedLocalPaths, visitedImports, depth+1)
 		for k, dd := range result.dependencyManagement {
 			// Evaluate variables and overwrite dependencyManagement
 			result.dependencyManagement[k] = dd.Resolve(newProps, nil, nil)
@@ -718,24 +938,50 @@ func (p *Parser) tryRepository(groupID, artifactID, version string) (*pom, error
 		return nil, xerrors.Errorf("Version missing for %s:%s", groupID, artifactID)
 	}
 
+	// Resolve `LATEST`/`RELEASE` markers and version ranges (`[1.0,2.0)`, ...) to a
+	// concrete version via the artifact-level maven-metadata.xml before building a path.
+	if needsVersionResolution(version) {
+		resolved, err := p.resolveVersion(groupID, artifactID, version)
+		if err != nil {
+			return nil, xerrors.Errorf("unable to resolve version %s for %s:%s: %w", version, groupID, artifactID, err)
+		}
+		version = resolved
+	}
+
 	// Generate a proper path to the pom.xml
 	// e.g. com.fasterxml.jackson.core, jackson-annotations, 2.10.0
 	//      => com/fasterxml/jackson/core/jackson-annotations/2.10.0/jackson-annotations-2.10.0.pom
 	paths := strings.Split(groupID, ".")
 	paths = append(paths, artifactID, version, fmt.Sprintf("%s-%s.pom", artifactID, version))
 
-	// Search local remoteRepositories
-	loaded, err := p.loadPOMFromLocalRepository(paths)
-	if err == nil {
-		return loaded, nil
+	// Search the local Maven repository first when it was opted into, mirroring Maven's
+	// own resolution order. In offline mode it's the only source we can possibly use.
+	if p.offline || p.useMavenLocalRepository {
+		loaded, err := p.loadPOMFromLocalRepository(paths)
+		if err == nil {
+			return loaded, nil
+		}
+		p.logger.Debug("POM not found in the local Maven repository", log.String("path", filepath.Join(paths...)))
 	}
 
 	// Search remote remoteRepositories
-	loaded, err = p.fetchPOMFromRemoteRepositories(paths, isSnapshot(version))
+	loaded, err := p.fetchPOMFromRemoteRepositories(paths, isSnapshot(version))
 	if err == nil {
 		return loaded, nil
 	}
 
+	// As a last resort, consult deps.dev for artifacts we can't reach in any
+	// configured repository (e.g. the true POM lives behind a private repo).
+	// Never in offline mode: deps.dev is a network fallback, and offline scans
+	// must not reach out to anything beyond the local Maven repository.
+	if p.depsDevFallback && !p.offline {
+		loaded, depsDevErr := p.fetchPOMFromDepsDev(groupID, artifactID, version)
+		if depsDevErr == nil {
+			return loaded, nil
+		}
+		p.logger.Debug("deps.dev fallback failed", log.Err(depsDevErr))
+	}
+
 	return nil, xerrors.Errorf("%s:%s:%s was not found in local/remote repositories", groupID, artifactID, version)
 }
 
@@ -746,6 +992,19 @@ func (p *Parser) loadPOMFromLocalRepository(paths []string) (*pom, error) {
 	return p.openPom(localPath)
 }
 
+// defaultMaxConcurrentFetches is used when WithMaxConcurrentFetches isn't set.
+const defaultMaxConcurrentFetches = 4
+
+// fetchResult is one remoteRepository's outcome, fanned in on resultsCh.
+type fetchResult struct {
+	pom *pom
+	err error
+}
+
+// fetchPOMFromRemoteRepositories queries remoteRepos concurrently (bounded by
+// WithMaxConcurrentFetches) and returns the first successful 200, cancelling
+// the remaining in-flight fetches. Errors from repositories that never
+// produce a POM are aggregated and only returned if none of them succeed.
 func (p *Parser) fetchPOMFromRemoteRepositories(paths []string, snapshot bool) (*pom, error) {
 	// Do not try fetching pom.xml from remote repositories in offline mode
 	if p.offline {
@@ -758,98 +1017,220 @@ func (p *Parser) fetchPOMFromRemoteRepositories(paths []string, snapshot bool) (
 	if snapshot {
 		remoteRepos = p.snapshotRemoteRepos
 	}
+	if len(remoteRepos) == 0 {
+		return nil, xerrors.Errorf("the POM was not found in remote remoteRepositories")
+	}
 
-	// try all remoteRepositories
-	for _, repo := range remoteRepos {
-		repoPaths := slices.Clone(paths) // Clone slice to avoid overwriting last element of `paths`
-		if snapshot {
-			pomFileName, err := p.fetchPomFileNameFromMavenMetadata(repo, repoPaths)
-			if err != nil {
-				return nil, xerrors.Errorf("fetch maven-metadata.xml error: %w", err)
+	maxConcurrent := p.maxConcurrentFetches
+	if maxConcurrent <= 0 {
+		maxConcurrent = defaultMaxConcurrentFetches
+	}
+	workers := maxConcurrent
+	if len(remoteRepos) < workers {
+		workers = len(remoteRepos)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	reposCh := make(chan string)
+	resultsCh := make(chan fetchResult, len(remoteRepos))
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for repo := range reposCh {
+				if ctx.Err() != nil {
+					return
+				}
+				resultsCh <- p.fetchPOMFromRemoteRepositoryCtx(ctx, repo, paths, snapshot)
 			}
-			// Use file name from `maven-metadata.xml` if it exists
-			if pomFileName != "" {
-				repoPaths[len(repoPaths)-1] = pomFileName
+		}()
+	}
+
+	go func() {
+		defer close(reposCh)
+		for _, repo := range remoteRepos {
+			select {
+			case reposCh <- repo:
+			case <-ctx.Done():
+				return
 			}
 		}
-		fetched, err := p.fetchPOMFromRemoteRepository(repo, repoPaths)
-		if err != nil {
-			return nil, xerrors.Errorf("fetch repository error: %w", err)
-		} else if fetched == nil {
-			continue
+	}()
+
+	go func() {
+		wg.Wait()
+		close(resultsCh)
+	}()
+
+	var errs error
+	for res := range resultsCh {
+		switch {
+		case res.pom != nil:
+			cancel()
+			return res.pom, nil
+		case res.err != nil:
+			errs = multierror.Append(errs, res.err)
 		}
-		return fetched, nil
+	}
+
+	if errs != nil {
+		return nil, errs
 	}
 	return nil, xerrors.Errorf("the POM was not found in remote remoteRepositories")
 }
 
-func (p *Parser) remoteRepoRequest(repo string, paths []string) (*http.Request, error) {
+// fetchPOMFromRemoteRepositoryCtx fetches from a single repo, bailing out
+// early if ctx has already been cancelled by another worker's success.
+func (p *Parser) fetchPOMFromRemoteRepositoryCtx(ctx context.Context, repo string, paths []string, snapshot bool) fetchResult {
+	repoPaths := slices.Clone(paths) // Clone slice to avoid overwriting last element of `paths`
+	if snapshot {
+		pomFileName, err := p.fetchPomFileNameFromMavenMetadata(ctx, repo, repoPaths)
+		if err != nil {
+			return fetchResult{err: xerrors.Errorf("fetch maven-metadata.xml error: %w", err)}
+		}
+		// Use file name from `maven-metadata.xml` if it exists
+		if pomFileName != "" {
+			repoPaths[len(repoPaths)-1] = pomFileName
+		}
+	}
+
+	if ctx.Err() != nil {
+		return fetchResult{}
+	}
+
+	fetched, err := p.fetchPOMFromRemoteRepository(ctx, repo, repoPaths)
+	if err != nil {
+		return fetchResult{err: xerrors.Errorf("fetch repository error: %w", err)}
+	}
+	return fetchResult{pom: fetched}
+}
+
+// remoteRepoRequest builds the request for paths on repo, bound to ctx so that
+// cancelling ctx (e.g. once another repository in a concurrent fan-out has
+// already succeeded) aborts an in-flight transfer instead of letting it run to
+// completion uncancelled.
+func (p *Parser) remoteRepoRequest(ctx context.Context, repo string, paths []string) (*http.Request, error) {
 	repoURL, err := url.Parse(repo)
 	if err != nil {
 		return nil, xerrors.Errorf("unable to parse URL: %w", err)
 	}
 
+	// Rewrite the repository URL through a matching `<mirror>`.
+	repoID := repositoryID(repo)
+	mirrored := repo
+	if m, ok := mavensettings.MatchMirror(p.settings.Mirrors, repoID); ok {
+		mirrored = m.URL
+	}
+	if mirrored != repo {
+		p.logger.Debug("Repository mirrored", log.String("repo", repo), log.String("mirror", mirrored))
+		if repoURL, err = url.Parse(mirrored); err != nil {
+			return nil, xerrors.Errorf("unable to parse mirror URL: %w", err)
+		}
+	}
+
 	paths = append([]string{repoURL.Path}, paths...)
 	repoURL.Path = path.Join(paths...)
 
-	req, err := http.NewRequest("GET", repoURL.String(), http.NoBody)
+	req, err := http.NewRequestWithContext(ctx, "GET", repoURL.String(), http.NoBody)
 	if err != nil {
 		return nil, xerrors.Errorf("unable to create HTTP request: %w", err)
 	}
-	if repoURL.User != nil {
+
+	switch {
+	case repoURL.User != nil:
 		password, _ := repoURL.User.Password()
 		req.SetBasicAuth(repoURL.User.Username(), password)
+	default:
+		if s, ok := mavensettings.ServerFor(p.settings.Servers, repoID); ok {
+			if header, ok := mavensettings.AuthHeader(s); ok {
+				req.Header.Set("Authorization", header)
+			} else if s.Username != "" {
+				req.SetBasicAuth(s.Username, s.Password)
+			}
+		}
 	}
 
 	return req, nil
 }
 
-var client = &http.Client{}
-
-func httpRequest(req *http.Request) ([]byte, int, error) {
-	var resp *http.Response
-	var err error
-	var statusCode int = 0
-	var data = []byte{}
-
-	resp, err = client.Do(req)
-
-	// HTTP request was made successfully (doesn't mean it was a 2xx, just that the client did not return an error)
-	if err == nil {
-		defer resp.Body.Close()
-
-		statusCode = resp.StatusCode
+// repositoryID returns the Maven repository id used for `<mirrorOf>`/`<server>`
+// id matching. Trivy's remote repositories are a plain list of URLs with no
+// separate `<id>` the way a real `<repositories>` entry would carry one, so
+// the well-known ones map to their conventional Maven id -- most importantly
+// "central", the repository virtually every settings.xml mirrors -- and
+// anything else falls back to its hostname.
+func repositoryID(repo string) string {
+	if repo == defaultCentralUrl {
+		return "central"
+	}
+	u, err := url.Parse(repo)
+	if err != nil {
+		return repo
+	}
+	return u.Hostname()
+}
 
-		// Read response body
-		data, err = io.ReadAll(resp.Body)
+// httpClientTimeout bounds a single HTTP round trip against a remote
+// repository. Without it, a repository that accepts the connection but never
+// responds (or trickles bytes indefinitely) would hang a fetch forever whenever
+// no other repository succeeds first: the fan-out's context is only cancelled
+// once another repository wins, and requests built with context.Background()
+// (version resolution, deps.dev) are never cancelled at all.
+const httpClientTimeout = 30 * time.Second
 
-		if err != nil {
-			return nil, statusCode, err
-		}
+var client = &http.Client{Timeout: httpClientTimeout}
 
-		return data, statusCode, nil
-	} else {
-		// Error when making HTTP request
-		return nil, statusCode, err
-	}
+func (p *Parser) httpClient(req *http.Request) *http.Client {
+	return httpClientForProxy(proxyForRepo(p.settings.Proxies, req.URL.String()))
 }
 
 // performs an HTTP request with caching support (if enabled)
 func (p *Parser) cachedHTTPRequest(req *http.Request, path string) ([]byte, int, error) {
+	return p.cachedHTTPRequestWithCache(&p.mavenHttpCacheMu, p.mavenHttpCache, p.pomValidatorCache, req, path)
+}
+
+// cachedMetadataHTTPRequest is like cachedHTTPRequest, but uses the dedicated
+// metadataHttpCache (shorter TTL) when one is configured, since maven-metadata.xml
+// -- unlike a concrete `-x.y.z.pom` -- changes frequently for SNAPSHOT artifacts.
+func (p *Parser) cachedMetadataHTTPRequest(req *http.Request, path string) ([]byte, int, error) {
+	cache := p.metadataHttpCache
+	vcache := p.metadataValidatorCache
+	mu := &p.metadataHttpCacheMu
+	if cache == nil {
+		cache = p.mavenHttpCache
+		vcache = p.pomValidatorCache
+		mu = &p.mavenHttpCacheMu
+	}
+	return p.cachedHTTPRequestWithCache(mu, cache, vcache, req, path)
+}
+
+// cachedHTTPRequestWithCache reads/writes cache under mu: that type predates
+// the concurrent repository fan-out and isn't safe for concurrent access on
+// its own, so every get/set/domainTimeouts/blocklistDomain touch is locked --
+// but the network round trip itself happens outside the lock, so concurrent
+// fetches against different repositories still run in parallel.
+func (p *Parser) cachedHTTPRequestWithCache(mu *sync.Mutex, cache *mavenHttpCache, vcache *validatorCache, req *http.Request, path string) ([]byte, int, error) {
 	var err error
 	var statusCode int = 0
 	var data = []byte{}
 
 	// E.g. if the cache is disabled, make a regular HTTP request without caching
-	if p.mavenHttpCache == nil {
-		data, statusCode, err = httpRequest(req)
+	if cache == nil {
+		data, statusCode, err = httpRequestWithRetry(req, p.httpClient(req))
 		return data, statusCode, err
 	}
 
 	url := req.URL.String()
 
-	if entry, err := p.mavenHttpCache.get(path); err != nil {
-		p.logger.Debug("Cache read error", log.String("url", url), log.String("path", path), log.Err(err))
+	mu.Lock()
+	entry, getErr := cache.get(path)
+	mu.Unlock()
+	if getErr != nil {
+		p.logger.Debug("Cache read error", log.String("url", url), log.String("path", path), log.Err(getErr))
 	} else if entry != nil {
 		p.logger.Debug("Cache hit", log.String("url", url), log.String("path", path))
 		return entry.Data, entry.StatusCode, nil
@@ -857,48 +1238,87 @@ func (p *Parser) cachedHTTPRequest(req *http.Request, path string) ([]byte, int,
 		p.logger.Debug("Cache miss, making HTTP request", log.String("url", url), log.String("path", path))
 	}
 
-	if p.mavenHttpCache.isDomainBlocklisted(req.URL.Host) {
+	// The underlying entry above is gone or expired, but we may still hold a
+	// stale body plus the validators needed to cheaply confirm it's unchanged.
+	// vcache (validatorCache) locks itself internally, so it needs no mu here.
+	var stale *validatorEntry
+	if vcache != nil {
+		if ve, fresh := vcache.get(path); ve != nil && !fresh {
+			stale = ve
+			applyConditionalHeaders(req, ve)
+		}
+	}
+
+	mu.Lock()
+	blocklisted := cache.isDomainBlocklisted(req.URL.Host)
+	mu.Unlock()
+
+	if blocklisted {
 		p.logger.Debug(
 			fmt.Sprintf("Domain %s is blocklisted, assuming 404", req.URL.Host),
 		)
 		return nil, http.StatusNotFound, nil
 	} else {
-		data, statusCode, err = httpRequest(req)
+		data, statusCode, err = httpRequestWithRetry(req, p.httpClient(req))
 
 		// Error when making HTTP request
 		if err != nil {
 			p.logger.Debug("HTTP error", log.String("url", url), log.String("path", path), log.Err(err))
 
 			if strings.Contains(err.Error(), "i/o timeout") {
-				p.mavenHttpCache.domainTimeouts[req.URL.Host]++
-
-				p.logger.Debug(
-					"I/O timeout, falling back to 404",
-					log.Int(fmt.Sprintf("numTimeouts[%s]", req.URL.Host), p.mavenHttpCache.domainTimeouts[req.URL.Host]),
-				)
-
-				if p.mavenHttpCache.domainTimeouts[req.URL.Host] >= MaxDomainTimeouts {
+				mu.Lock()
+				cache.domainTimeouts[req.URL.Host]++
+				numTimeouts := cache.domainTimeouts[req.URL.Host]
+				if numTimeouts >= MaxDomainTimeouts {
 					p.logger.Warn(
 						fmt.Sprintf("Blocklisting domain %s due to too many timeouts", req.URL.Host),
 					)
-
-					err = p.mavenHttpCache.blocklistDomain(req.URL.Host)
+					err = cache.blocklistDomain(req.URL.Host)
 				}
+				mu.Unlock()
+
+				p.logger.Debug(
+					"I/O timeout, falling back to 404",
+					log.Int(fmt.Sprintf("numTimeouts[%s]", req.URL.Host), numTimeouts),
+				)
 
 				return nil, http.StatusNotFound, err
+			} else if stale != nil {
+				p.logger.Debug("HTTP error, serving stale cached response", log.String("url", url), log.String("path", path), log.Err(err))
+				return stale.Data, stale.StatusCode, nil
 			} else {
 				return nil, statusCode, err
 			}
 		}
+
+		if statusCode == http.StatusNotModified && stale != nil {
+			p.logger.Debug("Not modified, refreshing cached entry", log.String("url", url), log.String("path", path))
+			vcache.touch(path)
+			mu.Lock()
+			cacheErr := cache.set(url, path, stale.Data, stale.StatusCode)
+			mu.Unlock()
+			if cacheErr != nil {
+				p.logger.Debug("Failed to refresh cached response", log.String("url", url), log.String("path", path), log.Err(cacheErr))
+			}
+			return stale.Data, stale.StatusCode, nil
+		}
 	}
 
 	// Cache 2xx or 404 (we don't want to keep fetching artifacts that are not found via 404)
 	if statusCode == http.StatusOK || statusCode == http.StatusNotFound {
-		if cacheErr := p.mavenHttpCache.set(url, path, data, statusCode); cacheErr != nil {
+		mu.Lock()
+		cacheErr := cache.set(url, path, data, statusCode)
+		mu.Unlock()
+		if cacheErr != nil {
 			p.logger.Debug("Failed to cache response", log.String("url", url), log.String("path", path), log.Err(cacheErr))
 		} else {
 			p.logger.Debug("Cached response", log.String("url", url), log.String("path", path))
 		}
+
+		if vcache != nil && statusCode == http.StatusOK {
+			etag, lastModified := responseValidators(req.Response)
+			vcache.store(path, data, statusCode, etag, lastModified)
+		}
 	} else {
 		p.logger.Debug("Response not successful, no caching", log.String("url", url), log.String("path", path), log.Int("statusCode", statusCode))
 	}
@@ -907,18 +1327,18 @@ func (p *Parser) cachedHTTPRequest(req *http.Request, path string) ([]byte, int,
 }
 
 // fetchPomFileNameFromMavenMetadata fetches `maven-metadata.xml` file to detect file name of pom file.
-func (p *Parser) fetchPomFileNameFromMavenMetadata(repo string, paths []string) (string, error) {
+func (p *Parser) fetchPomFileNameFromMavenMetadata(ctx context.Context, repo string, paths []string) (string, error) {
 	// Overwrite pom file name to `maven-metadata.xml`
 	mavenMetadataPaths := slices.Clone(paths[:len(paths)-1]) // Clone slice to avoid shadow overwriting last element of `paths`
 	mavenMetadataPaths = append(mavenMetadataPaths, "maven-metadata.xml")
 
-	req, err := p.remoteRepoRequest(repo, mavenMetadataPaths)
+	req, err := p.remoteRepoRequest(ctx, repo, mavenMetadataPaths)
 	if err != nil {
 		p.logger.Debug("Unable to create request", log.String("repo", repo), log.Err(err))
 		return "", nil
 	}
 
-	data, statusCode, err := p.cachedHTTPRequest(req, strings.Join(mavenMetadataPaths, "/"))
+	data, statusCode, err := p.cachedMetadataHTTPRequest(req, strings.Join(mavenMetadataPaths, "/"))
 	if err != nil {
 		p.logger.Debug("Failed to fetch", log.String("url", req.URL.String()), log.Err(err))
 		return "", nil
@@ -943,8 +1363,8 @@ func (p *Parser) fetchPomFileNameFromMavenMetadata(repo string, paths []string)
 	return pomFileName, nil
 }
 
-func (p *Parser) fetchPOMFromRemoteRepository(repo string, paths []string) (*pom, error) {
-	req, err := p.remoteRepoRequest(repo, paths)
+func (p *Parser) fetchPOMFromRemoteRepository(ctx context.Context, repo string, paths []string) (*pom, error) {
+	req, err := p.remoteRepoRequest(ctx, repo, paths)
 	if err != nil {
 		p.logger.Debug("Unable to create request", log.String("repo", repo), log.Err(err))
 		return nil, nil
@@ -959,6 +1379,14 @@ func (p *Parser) fetchPOMFromRemoteRepository(repo string, paths []string) (*pom
 		return nil, nil
 	}
 
+	if err = p.verifyChecksum(ctx, repo, paths, data); err != nil {
+		if p.checksumPolicy == ChecksumRequire {
+			p.logger.Warn("Rejecting POM that failed checksum verification", log.String("url", req.URL.String()), log.Err(err))
+			return nil, nil
+		}
+		p.logger.Warn("Checksum verification failed, continuing anyway", log.String("url", req.URL.String()), log.Err(err))
+	}
+
 	content, err := parsePom(strings.NewReader(string(data)))
 	if err != nil {
 		return nil, xerrors.Errorf("failed to parse the remote POM: %w", err)
@@ -0,0 +1,74 @@
+package pom
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddMissingProperties(t *testing.T) {
+	ancestor := map[string]string{"java.version": "11", "revision": "1.0"}
+	props := map[string]string{"revision": "2.0"}
+
+	got := addMissingProperties(props, ancestor)
+
+	assert.Equal(t, "11", got["java.version"], "property only defined by an ancestor is filled in")
+	assert.Equal(t, "2.0", got["revision"], "nearest definition in the hierarchy always wins over an ancestor's")
+}
+
+func TestAddMissingPropertiesNoAncestor(t *testing.T) {
+	props := map[string]string{"revision": "2.0"}
+	assert.Equal(t, props, addMissingProperties(props, nil))
+}
+
+func TestIsSnapshot(t *testing.T) {
+	assert.True(t, isSnapshot("1.0.0-SNAPSHOT"))
+	assert.True(t, isSnapshot("LATEST"))
+	assert.False(t, isSnapshot("1.0.0"))
+	assert.False(t, isSnapshot("RELEASE"))
+}
+
+// writeLocalRepoPom places a minimal, parseable pom.xml at the path the local
+// Maven repository convention expects for groupID:artifactID:version.
+func writeLocalRepoPom(t *testing.T, repoDir, groupID, artifactID, version string) {
+	t.Helper()
+
+	segments := append(strings.Split(groupID, "."), artifactID, version)
+	dir := filepath.Join(append([]string{repoDir}, segments...)...)
+	assert.NoError(t, os.MkdirAll(dir, 0o755))
+
+	pomPath := filepath.Join(dir, artifactID+"-"+version+".pom")
+	assert.NoError(t, os.WriteFile(pomPath, []byte("<project></project>"), 0o644))
+}
+
+func TestShouldFollowImport(t *testing.T) {
+	visited := make(map[string]struct{})
+
+	assert.True(t, shouldFollowImport(visited, "com.example:bom:1.0"), "an unvisited BOM should be followed")
+	assert.False(t, shouldFollowImport(visited, "com.example:bom:1.0"), "a BOM already visited must not be followed again, even via a different import path")
+	assert.True(t, shouldFollowImport(visited, "com.example:other-bom:1.0"), "a distinct BOM is unaffected by another BOM's visited state")
+}
+
+func TestTryRepositorySkipsLocalRepositoryUnlessOptedIn(t *testing.T) {
+	localRepo := t.TempDir()
+	writeLocalRepoPom(t, localRepo, "com.example", "my-lib", "1.0.0")
+
+	// No remote repositories configured, so a fetch that reaches the remote
+	// path always fails -- letting us tell the two resolution orders apart.
+	pDefault := NewParser("", WithMavenLocalRepositoryDir(localRepo), WithReleaseRemoteRepos(nil))
+	_, err := pDefault.tryRepository("com.example", "my-lib", "1.0.0")
+	assert.Error(t, err, "local repository must not be consulted unless opted in or offline")
+
+	pOptedIn := NewParser("", WithMavenLocalRepositoryDir(localRepo), WithUseMavenLocalRepository(true), WithReleaseRemoteRepos(nil))
+	pom, err := pOptedIn.tryRepository("com.example", "my-lib", "1.0.0")
+	assert.NoError(t, err)
+	assert.NotNil(t, pom)
+
+	pOffline := NewParser("", WithMavenLocalRepositoryDir(localRepo), WithOffline(true), WithReleaseRemoteRepos(nil))
+	pom, err = pOffline.tryRepository("com.example", "my-lib", "1.0.0")
+	assert.NoError(t, err, "offline mode must use the local repository even without WithUseMavenLocalRepository")
+	assert.NotNil(t, pom)
+}
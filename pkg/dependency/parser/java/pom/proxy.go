@@ -0,0 +1,67 @@
+package pom
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/aquasecurity/trivy/pkg/dependency/parser/java/pom/mavensettings"
+)
+
+// proxyForRepo returns the active proxy, if any, that should be used to reach repoURL.
+func proxyForRepo(proxies []mavensettings.Proxy, repoURL string) *mavensettings.Proxy {
+	u, err := url.Parse(repoURL)
+	if err != nil {
+		return nil
+	}
+
+	for i, px := range proxies {
+		if !px.Active {
+			continue
+		}
+		if px.Protocol != "" && px.Protocol != u.Scheme {
+			continue
+		}
+		if nonProxyHostMatches(px.NonProxyHosts, u.Hostname()) {
+			continue
+		}
+		return &proxies[i]
+	}
+	return nil
+}
+
+func nonProxyHostMatches(nonProxyHosts, host string) bool {
+	if nonProxyHosts == "" {
+		return false
+	}
+	for _, pattern := range strings.Split(nonProxyHosts, "|") {
+		pattern = strings.TrimSpace(pattern)
+		pattern = strings.ReplaceAll(pattern, "*", "")
+		if pattern != "" && strings.Contains(host, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// httpClientForProxy returns an *http.Client routed through px, or the shared
+// default client when px is nil.
+func httpClientForProxy(px *mavensettings.Proxy) *http.Client {
+	if px == nil {
+		return client
+	}
+
+	proxyURL := &url.URL{
+		Scheme: px.Protocol,
+		Host:   px.Host + ":" + strconv.Itoa(px.Port),
+	}
+	if px.Username != "" {
+		proxyURL.User = url.UserPassword(px.Username, px.Password)
+	}
+
+	return &http.Client{
+		Transport: &http.Transport{Proxy: http.ProxyURL(proxyURL)},
+		Timeout:   httpClientTimeout,
+	}
+}
@@ -0,0 +1,59 @@
+package pom
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aquasecurity/trivy/pkg/dependency/parser/java/pom/mavensettings"
+	"github.com/aquasecurity/trivy/pkg/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepositoryID(t *testing.T) {
+	assert.Equal(t, "central", repositoryID(defaultCentralUrl))
+	assert.Equal(t, "repo.example.com", repositoryID("https://repo.example.com/maven2/"))
+}
+
+func TestRemoteRepoRequestMatchesMirrorByRepositoryID(t *testing.T) {
+	// The textbook settings.xml case: <mirrorOf>central</mirrorOf>, matched
+	// against the Maven repository id, not the request URL's hostname.
+	p := &Parser{
+		logger: log.WithPrefix("pom"),
+		settings: &mavensettings.Settings{
+			Mirrors: []mavensettings.Mirror{
+				{ID: "internal-mirror", URL: "https://mirror.internal/maven2", MirrorOf: "central"},
+			},
+		},
+	}
+
+	req, err := p.remoteRepoRequest(context.Background(), defaultCentralUrl, []string{"com", "example", "1.0", "example-1.0.pom"})
+	assert.NoError(t, err)
+	assert.Equal(t, "mirror.internal", req.URL.Hostname())
+}
+
+func TestRemoteRepoRequestServerAuthMatchesRepositoryID(t *testing.T) {
+	p := &Parser{
+		logger: log.WithPrefix("pom"),
+		settings: &mavensettings.Settings{
+			Servers: []mavensettings.Server{
+				{ID: "central", BearerToken: "tok-123"},
+			},
+		},
+	}
+
+	req, err := p.remoteRepoRequest(context.Background(), defaultCentralUrl, []string{"com", "example", "1.0", "example-1.0.pom"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer tok-123", req.Header.Get("Authorization"))
+}
+
+func TestProxyForRepo(t *testing.T) {
+	proxies := []mavensettings.Proxy{
+		{Active: true, Protocol: "https", Host: "proxy.example.com", Port: 8080},
+	}
+
+	px := proxyForRepo(proxies, "https://repo.maven.apache.org/maven2/")
+	assert.NotNil(t, px)
+	assert.Equal(t, "proxy.example.com", px.Host)
+
+	assert.Nil(t, proxyForRepo(proxies, "http://repo.maven.apache.org/maven2/"), "proxy scoped to https must not match http")
+}
@@ -0,0 +1,110 @@
+package pom
+
+import (
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	maxFetchAttempts  = 3
+	initialRetryDelay = 200 * time.Millisecond
+	maxRetryDelay     = 2 * time.Second
+)
+
+// isRetryableStatus reports whether statusCode is a transient failure worth
+// retrying, as opposed to a genuine 404 (not found) which should be accepted
+// (and negatively cached) immediately.
+func isRetryableStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// parseRetryAfter parses a `Retry-After` header value expressed in seconds
+// (the HTTP-date form isn't handled, since Maven repositories use seconds).
+func parseRetryAfter(header string) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+	seconds, err := strconv.Atoi(header)
+	if err != nil || seconds < 0 {
+		return 0, false
+	}
+	return time.Duration(seconds) * time.Second, true
+}
+
+// withJitter returns d scaled by a random factor in [0.5, 1.5), to avoid many
+// concurrent fetches retrying in lockstep.
+func withJitter(d time.Duration) time.Duration {
+	return time.Duration(float64(d) * (0.5 + rand.Float64()))
+}
+
+func nextBackoff(d time.Duration) time.Duration {
+	d *= 2
+	if d > maxRetryDelay {
+		return maxRetryDelay
+	}
+	return d
+}
+
+// httpRequestWithRetry performs req, retrying transient 429/502/503/504 responses
+// with exponential backoff (honoring `Retry-After` when present). A true `i/o
+// timeout` network error is returned immediately so the caller's domain-blocklist
+// handling (which tracks those separately) still sees every one of them.
+func httpRequestWithRetry(req *http.Request, cl *http.Client) ([]byte, int, error) {
+	delay := initialRetryDelay
+
+	var data []byte
+	var statusCode int
+	var err error
+
+	for attempt := 1; attempt <= maxFetchAttempts; attempt++ {
+		data, statusCode, err = doHTTPRequest(req, cl)
+		if err != nil {
+			if strings.Contains(err.Error(), "i/o timeout") || attempt == maxFetchAttempts {
+				return data, statusCode, err
+			}
+			time.Sleep(withJitter(delay))
+			delay = nextBackoff(delay)
+			continue
+		}
+
+		if !isRetryableStatus(statusCode) || attempt == maxFetchAttempts {
+			return data, statusCode, nil
+		}
+
+		wait := withJitter(delay)
+		if ra, ok := parseRetryAfter(req.Response.Header.Get("Retry-After")); ok {
+			wait = ra
+		}
+		time.Sleep(wait)
+		delay = nextBackoff(delay)
+	}
+
+	return data, statusCode, err
+}
+
+// doHTTPRequest performs a single attempt, stashing the response on req.Response
+// so callers can read its headers (Retry-After in httpRequestWithRetry,
+// ETag/Last-Modified in cachedHTTPRequestWithCache) after the body is consumed.
+func doHTTPRequest(req *http.Request, cl *http.Client) ([]byte, int, error) {
+	resp, err := cl.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	req.Response = resp
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, resp.StatusCode, err
+	}
+	return data, resp.StatusCode, nil
+}
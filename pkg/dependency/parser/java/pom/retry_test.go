@@ -0,0 +1,63 @@
+package pom
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsRetryableStatus(t *testing.T) {
+	tests := []struct {
+		statusCode int
+		want       bool
+	}{
+		{http.StatusTooManyRequests, true},
+		{http.StatusBadGateway, true},
+		{http.StatusServiceUnavailable, true},
+		{http.StatusGatewayTimeout, true},
+		{http.StatusNotFound, false},
+		{http.StatusOK, false},
+		{http.StatusInternalServerError, false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, isRetryableStatus(tt.statusCode), "statusCode=%d", tt.statusCode)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		header string
+		want   time.Duration
+		wantOk bool
+	}{
+		{"", 0, false},
+		{"5", 5 * time.Second, true},
+		{"0", 0, true},
+		{"-1", 0, false},
+		{"not-a-number", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseRetryAfter(tt.header)
+		assert.Equal(t, tt.wantOk, ok, "header=%q", tt.header)
+		if ok {
+			assert.Equal(t, tt.want, got, "header=%q", tt.header)
+		}
+	}
+}
+
+func TestNextBackoff(t *testing.T) {
+	assert.Equal(t, 400*time.Millisecond, nextBackoff(200*time.Millisecond))
+	assert.Equal(t, maxRetryDelay, nextBackoff(maxRetryDelay))
+	assert.Equal(t, maxRetryDelay, nextBackoff(maxRetryDelay*10))
+}
+
+func TestWithJitter(t *testing.T) {
+	d := 1 * time.Second
+	for i := 0; i < 50; i++ {
+		got := withJitter(d)
+		assert.GreaterOrEqual(t, got, time.Duration(float64(d)*0.5))
+		assert.Less(t, got, time.Duration(float64(d)*1.5))
+	}
+}
@@ -0,0 +1,102 @@
+package pom
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// validatorEntry is a conditional-revalidation record for one cached path: the
+// last known-good body plus the validators (`ETag`/`Last-Modified`) needed to
+// ask the remote repository "has this changed?" instead of re-downloading it.
+type validatorEntry struct {
+	Data         []byte
+	StatusCode   int
+	ETag         string
+	LastModified string
+	FetchedAt    time.Time
+}
+
+// validatorCache holds validatorEntry records, each with its own freshness
+// window. Unlike mavenHttpCache (a one-shot cache that is either a hit or a
+// miss), an entry here stays useful past its TTL: once stale it's still
+// returned to the caller so a conditional GET can be attempted, and serving
+// it as-is is the correct fallback if that conditional GET fails outright.
+type validatorCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]*validatorEntry
+}
+
+func newValidatorCache(ttl time.Duration) *validatorCache {
+	return &validatorCache{
+		ttl:     ttl,
+		entries: make(map[string]*validatorEntry),
+	}
+}
+
+// get returns the entry cached for path, if any, and whether it is still
+// within its freshness window. A non-nil, non-fresh entry should be
+// revalidated with a conditional request rather than treated as a miss.
+func (c *validatorCache) get(path string) (*validatorEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[path]
+	if !ok {
+		return nil, false
+	}
+	return e, time.Since(e.FetchedAt) < c.ttl
+}
+
+// store records data as the latest known-good response for path, along with
+// the validators needed to revalidate it later. Entries without any
+// validator are not worth keeping, since they could never produce a 304.
+func (c *validatorCache) store(path string, data []byte, statusCode int, etag, lastModified string) {
+	if etag == "" && lastModified == "" {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[path] = &validatorEntry{
+		Data:         data,
+		StatusCode:   statusCode,
+		ETag:         etag,
+		LastModified: lastModified,
+		FetchedAt:    time.Now(),
+	}
+}
+
+// touch resets path's freshness window, used after a 304 confirms the
+// previously cached body is still current.
+func (c *validatorCache) touch(path string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.entries[path]; ok {
+		e.FetchedAt = time.Now()
+	}
+}
+
+// applyConditionalHeaders sets `If-None-Match`/`If-Modified-Since` on req from
+// a previously cached entry, so an unmodified remote resource costs a 304
+// instead of a full re-download.
+func applyConditionalHeaders(req *http.Request, e *validatorEntry) {
+	if e.ETag != "" {
+		req.Header.Set("If-None-Match", e.ETag)
+	}
+	if e.LastModified != "" {
+		req.Header.Set("If-Modified-Since", e.LastModified)
+	}
+}
+
+// responseValidators extracts the `ETag`/`Last-Modified` headers from resp,
+// if any, for later conditional revalidation.
+func responseValidators(resp *http.Response) (etag, lastModified string) {
+	if resp == nil {
+		return "", ""
+	}
+	return resp.Header.Get("ETag"), resp.Header.Get("Last-Modified")
+}
@@ -0,0 +1,68 @@
+package pom
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidatorCacheGetFreshness(t *testing.T) {
+	c := newValidatorCache(time.Minute)
+
+	_, ok := c.get("a.pom")
+	assert.False(t, ok, "unknown path has no entry")
+
+	c.store("a.pom", []byte("data"), http.StatusOK, `"etag-1"`, "")
+	entry, fresh := c.get("a.pom")
+	assert.NotNil(t, entry)
+	assert.True(t, fresh)
+
+	entry.FetchedAt = time.Now().Add(-2 * time.Minute)
+	_, fresh = c.get("a.pom")
+	assert.False(t, fresh, "entry past its TTL is stale but still returned for revalidation")
+}
+
+func TestValidatorCacheStoreWithoutValidatorsIsDiscarded(t *testing.T) {
+	c := newValidatorCache(time.Minute)
+	c.store("a.pom", []byte("data"), http.StatusOK, "", "")
+
+	_, ok := c.get("a.pom")
+	assert.False(t, ok, "an entry with no ETag/Last-Modified can never produce a 304, so it shouldn't be kept")
+}
+
+func TestValidatorCacheTouchResetsFreshness(t *testing.T) {
+	c := newValidatorCache(time.Minute)
+	c.store("a.pom", []byte("data"), http.StatusOK, `"etag-1"`, "")
+
+	entry, _ := c.get("a.pom")
+	entry.FetchedAt = time.Now().Add(-2 * time.Minute)
+
+	c.touch("a.pom")
+	_, fresh := c.get("a.pom")
+	assert.True(t, fresh)
+}
+
+func TestApplyConditionalHeaders(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "https://repo.example.com/a.pom", nil)
+	applyConditionalHeaders(req, &validatorEntry{ETag: `"etag-1"`, LastModified: "Mon, 01 Jan 2024 00:00:00 GMT"})
+
+	assert.Equal(t, `"etag-1"`, req.Header.Get("If-None-Match"))
+	assert.Equal(t, "Mon, 01 Jan 2024 00:00:00 GMT", req.Header.Get("If-Modified-Since"))
+}
+
+func TestResponseValidators(t *testing.T) {
+	etag, lastModified := responseValidators(nil)
+	assert.Empty(t, etag)
+	assert.Empty(t, lastModified)
+
+	resp := &http.Response{Header: http.Header{}}
+	resp.Header.Set("ETag", `"etag-2"`)
+	resp.Header.Set("Last-Modified", "Tue, 02 Jan 2024 00:00:00 GMT")
+
+	etag, lastModified = responseValidators(resp)
+	assert.Equal(t, `"etag-2"`, etag)
+	assert.Equal(t, "Tue, 02 Jan 2024 00:00:00 GMT", lastModified)
+}
@@ -0,0 +1,322 @@
+package pom
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/aquasecurity/trivy/pkg/log"
+	"golang.org/x/xerrors"
+)
+
+// artifactMetadata is the artifact-level `maven-metadata.xml` (as opposed to the
+// version-level one `fetchPomFileNameFromMavenMetadata` parses for SNAPSHOT
+// filenames): `groupId/artifactId/maven-metadata.xml`, listing every released
+// version plus the `LATEST`/`RELEASE` pointers.
+type artifactMetadata struct {
+	XMLName    xml.Name `xml:"metadata"`
+	Versioning struct {
+		Latest   string `xml:"latest"`
+		Release  string `xml:"release"`
+		Versions struct {
+			Version []string `xml:"version"`
+		} `xml:"versions"`
+	} `xml:"versioning"`
+}
+
+// needsVersionResolution reports whether version is a `LATEST`/`RELEASE` marker
+// or a Maven version range (`[1.0,2.0)`, `(,1.5]`, `[1.0,)`, ...) that must be
+// resolved against the artifact-level maven-metadata.xml before it can be fetched.
+func needsVersionResolution(version string) bool {
+	if version == "LATEST" || version == "RELEASE" {
+		return true
+	}
+	return strings.HasPrefix(version, "[") || strings.HasPrefix(version, "(")
+}
+
+// resolveVersion resolves a `LATEST`/`RELEASE`/range version marker to a concrete
+// version using the artifact-level maven-metadata.xml. It returns "" (with a nil
+// error) when version doesn't need resolving.
+func (p *Parser) resolveVersion(groupID, artifactID, version string) (string, error) {
+	if !needsVersionResolution(version) {
+		return "", nil
+	}
+	if p.offline {
+		return "", xerrors.New("cannot resolve LATEST/RELEASE/range versions in offline mode")
+	}
+
+	groupPath := strings.Split(groupID, ".")
+	metadataPaths := append(append([]string{}, groupPath...), artifactID, "maven-metadata.xml")
+
+	var errs error
+	for _, repo := range p.releaseRemoteRepos {
+		req, err := p.remoteRepoRequest(context.Background(), repo, metadataPaths)
+		if err != nil {
+			continue
+		}
+
+		data, statusCode, err := p.cachedMetadataHTTPRequest(req, strings.Join(metadataPaths, "/"))
+		if err != nil || statusCode != http.StatusOK {
+			continue
+		}
+
+		var md artifactMetadata
+		if err = xml.NewDecoder(strings.NewReader(string(data))).Decode(&md); err != nil {
+			p.logger.Debug("Unable to parse artifact-level maven-metadata.xml", log.String("repo", repo), log.Err(err))
+			continue
+		}
+
+		resolved, err := resolveVersionFromMetadata(version, md)
+		if err != nil {
+			errs = err
+			continue
+		}
+		p.logger.Debug("Resolved version marker", log.String("version", version), log.String("resolved", resolved))
+		return resolved, nil
+	}
+
+	if errs != nil {
+		return "", errs
+	}
+	return "", xerrors.Errorf("unable to resolve version %q for %s:%s", version, groupID, artifactID)
+}
+
+func resolveVersionFromMetadata(version string, md artifactMetadata) (string, error) {
+	switch version {
+	case "LATEST":
+		if md.Versioning.Latest == "" {
+			return "", xerrors.New("no <latest> version in maven-metadata.xml")
+		}
+		return md.Versioning.Latest, nil
+	case "RELEASE":
+		if md.Versioning.Release == "" {
+			return "", xerrors.New("no <release> version in maven-metadata.xml")
+		}
+		return md.Versioning.Release, nil
+	default:
+		return highestInRange(version, md.Versioning.Versions.Version)
+	}
+}
+
+// versionRange is a parsed Maven version range, e.g. `[1.0,2.0)`.
+type versionRange struct {
+	lowInclusive  bool
+	low           string
+	high          string
+	highInclusive bool
+}
+
+// parseVersionRange parses Maven version range syntax.
+// cf. https://maven.apache.org/pom.html#Dependency_Version_Requirement_Specification
+func parseVersionRange(s string) (versionRange, error) {
+	if len(s) < 3 {
+		return versionRange{}, xerrors.Errorf("invalid version range: %s", s)
+	}
+
+	lowInclusive := s[0] == '['
+	highInclusive := s[len(s)-1] == ']'
+	if (!lowInclusive && s[0] != '(') || (!highInclusive && s[len(s)-1] != ')') {
+		return versionRange{}, xerrors.Errorf("invalid version range: %s", s)
+	}
+
+	inner := s[1 : len(s)-1]
+	parts := strings.SplitN(inner, ",", 2)
+	if len(parts) != 2 {
+		// A single-version range, e.g. `[1.0]`, means exactly that version.
+		return versionRange{lowInclusive: true, low: inner, high: inner, highInclusive: true}, nil
+	}
+
+	return versionRange{
+		lowInclusive:  lowInclusive,
+		low:           strings.TrimSpace(parts[0]),
+		high:          strings.TrimSpace(parts[1]),
+		highInclusive: highInclusive,
+	}, nil
+}
+
+func (r versionRange) contains(version string) bool {
+	if r.low != "" {
+		cmp := compareMavenVersions(version, r.low)
+		if cmp < 0 || (cmp == 0 && !r.lowInclusive) {
+			return false
+		}
+	}
+	if r.high != "" {
+		cmp := compareMavenVersions(version, r.high)
+		if cmp > 0 || (cmp == 0 && !r.highInclusive) {
+			return false
+		}
+	}
+	return true
+}
+
+// highestInRange picks the highest version in versions that satisfies rangeStr.
+func highestInRange(rangeStr string, versions []string) (string, error) {
+	r, err := parseVersionRange(rangeStr)
+	if err != nil {
+		return "", err
+	}
+
+	var best string
+	for _, v := range versions {
+		if !r.contains(v) {
+			continue
+		}
+		if best == "" || compareMavenVersions(v, best) > 0 {
+			best = v
+		}
+	}
+	if best == "" {
+		return "", xerrors.Errorf("no version in %v satisfies range %s", versions, rangeStr)
+	}
+	return best, nil
+}
+
+// qualifierRank implements Maven's qualifier ordering:
+// alpha < beta < milestone < rc/cr < snapshot < "" (release) < sp.
+// cf. https://maven.apache.org/pom.html#Version_Order_Specification
+var qualifierRank = map[string]int{
+	"alpha":     0,
+	"beta":      1,
+	"milestone": 2,
+	"m":         2,
+	"rc":        3,
+	"cr":        3,
+	"snapshot":  4,
+	"":          5,
+	"ga":        5,
+	"final":     5,
+	"sp":        6,
+}
+
+func qualifierOf(s string) (int, bool) {
+	rank, ok := qualifierRank[strings.ToLower(s)]
+	return rank, ok
+}
+
+// compareMavenVersions compares two version strings per Maven's version ordering:
+// numeric segments compare numerically, and a trailing qualifier (alpha, beta,
+// milestone, rc, snapshot, "", sp) compares per qualifierRank instead of lexically.
+// It returns -1, 0, or 1, mirroring strings.Compare / sort.Interface conventions.
+func compareMavenVersions(a, b string) int {
+	aSegs := splitVersionSegments(a)
+	bSegs := splitVersionSegments(b)
+
+	for i := 0; i < max(len(aSegs), len(bSegs)); i++ {
+		var as, bs string
+		if i < len(aSegs) {
+			as = aSegs[i]
+		}
+		if i < len(bSegs) {
+			bs = bSegs[i]
+		}
+		if c := compareVersionSegment(as, bs); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// splitVersionSegments splits a version on '.', '-', and '_', which Maven treats
+// as equivalent segment separators.
+func splitVersionSegments(v string) []string {
+	return strings.FieldsFunc(v, func(r rune) bool {
+		return r == '.' || r == '-' || r == '_'
+	})
+}
+
+func compareVersionSegment(a, b string) int {
+	aNum, aIsNum := parseUint(a)
+	bNum, bIsNum := parseUint(b)
+
+	switch {
+	case aIsNum && bIsNum:
+		switch {
+		case aNum < bNum:
+			return -1
+		case aNum > bNum:
+			return 1
+		default:
+			return 0
+		}
+	case aIsNum && !bIsNum:
+		// A missing segment counts as 0 ("1.0" == "1.0.0", "1.0" < "1.0.1"); any
+		// other numeric segment outranks a qualifier segment (e.g. "1.0.1" > "1.0-rc1").
+		if b == "" {
+			return compareQualifierDigits(a, "")
+		}
+		return 1
+	case !aIsNum && bIsNum:
+		if a == "" {
+			return compareQualifierDigits("", b)
+		}
+		return -1
+	default:
+		// Real-world pre-release qualifiers almost always carry a fused trailing
+		// digit (`rc1`, `beta2`, `m1`), which qualifierRank doesn't recognize as-is.
+		// Split it off before the lookup, the way Maven's ComparableVersion does,
+		// so "RC1" still resolves to the "rc" rank instead of falling through to a
+		// raw string compare (where "rc1" > "" and a release candidate would wrongly
+		// outrank its final release).
+		aBase, aDigits := splitQualifierDigits(a)
+		bBase, bDigits := splitQualifierDigits(b)
+		aRank, aOk := qualifierOf(aBase)
+		bRank, bOk := qualifierOf(bBase)
+		if aOk && bOk {
+			switch {
+			case aRank < bRank:
+				return -1
+			case aRank > bRank:
+				return 1
+			default:
+				return compareQualifierDigits(aDigits, bDigits)
+			}
+		}
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	}
+}
+
+// splitQualifierDigits splits a qualifier token into its leading alphabetic
+// base and trailing numeric suffix, e.g. "rc1" -> ("rc", "1"), "beta" -> ("beta", "").
+func splitQualifierDigits(s string) (base, digits string) {
+	i := len(s)
+	for i > 0 && s[i-1] >= '0' && s[i-1] <= '9' {
+		i--
+	}
+	return s[:i], s[i:]
+}
+
+// compareQualifierDigits compares the numeric suffixes split off by
+// splitQualifierDigits, treating a missing suffix as 0 (e.g. "rc" < "rc1").
+func compareQualifierDigits(a, b string) int {
+	aNum, _ := parseUint(a)
+	bNum, _ := parseUint(b)
+	switch {
+	case aNum < bNum:
+		return -1
+	case aNum > bNum:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func parseUint(s string) (uint64, bool) {
+	if s == "" {
+		return 0, false
+	}
+	n, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
@@ -0,0 +1,68 @@
+package pom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareMavenVersions(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want int
+	}{
+		{
+			name: "release candidate is older than its final release",
+			a:    "2.0.0-RC1",
+			b:    "2.0.0",
+			want: -1,
+		},
+		{
+			name: "beta is older than release candidate",
+			a:    "1.0-beta2",
+			b:    "1.0-rc1",
+			want: -1,
+		},
+		{
+			name: "milestone tokens compare numerically",
+			a:    "1.0-m1",
+			b:    "1.0-m2",
+			want: -1,
+		},
+		{
+			name: "rc tokens with fused digits compare numerically",
+			a:    "1.0-rc2",
+			b:    "1.0-rc10",
+			want: -1,
+		},
+		{
+			name: "bare qualifier is older than its numbered variant",
+			a:    "1.0-rc",
+			b:    "1.0-rc1",
+			want: -1,
+		},
+		{
+			name: "identical versions are equal",
+			a:    "1.2.3",
+			b:    "1.2.3",
+			want: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, compareMavenVersions(tt.a, tt.b))
+			assert.Equal(t, -tt.want, compareMavenVersions(tt.b, tt.a))
+		})
+	}
+}
+
+func TestHighestInRange(t *testing.T) {
+	versions := []string{"1.0", "1.1", "2.0-RC1", "2.0"}
+
+	got, err := highestInRange("[1.0,)", versions)
+	assert.NoError(t, err)
+	assert.Equal(t, "2.0", got, "a release candidate must not outrank its final release")
+}